@@ -0,0 +1,229 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ContextListener is implemented by a Listener that wants to receive the
+// context passed to EmitAsyncContext, instead of being called with just
+// the event and its data.
+type ContextListener interface {
+	CallbackContext(ctx context.Context, event string, data ...interface{}) error
+}
+
+// ContextEmitter is implemented by the Emitters, such as the ones returned
+// by New and NewCommon, that also support context-aware emitting.
+//
+// It's a separate interface, instead of being folded into Emitter, so that
+// existing implementations of Emitter remain valid.
+type ContextEmitter interface {
+	Emitter
+
+	// EmitContext is the same as Emit, but stops invoking further listeners
+	// and returns ctx.Err() as soon as ctx is done.
+	EmitContext(ctx context.Context, event string, data ...interface{}) error
+
+	// EmitAsyncContext is the same as EmitAsync, but passes ctx to the
+	// listeners that implement ContextListener.
+	EmitAsyncContext(ctx context.Context, event string, data ...interface{}) Result
+}
+
+// ContextResult is implemented by the Result returned by EmitAsyncContext,
+// and lets the caller stop waiting as soon as ctx is done.
+type ContextResult interface {
+	Result
+
+	// WaitContext is the same as Wait, but returns ctx.Err() instead of
+	// blocking any further once ctx is done.
+	WaitContext(ctx context.Context) error
+}
+
+// TimeoutEmitter is implemented by the Emitters, such as the ones returned
+// by New and NewCommon, that support bounding every listener call with a
+// timeout so that a single slow listener can't stall Emit indefinitely.
+type TimeoutEmitter interface {
+	Emitter
+
+	// SetListenerTimeout bounds every subsequent listener call invoked by
+	// Emit or EmitContext with d.
+	//
+	// Zero, the default, means no timeout. Because Go has no way to
+	// forcibly cancel a running goroutine, a listener that times out keeps
+	// running in the background; Emit simply stops waiting for it.
+	SetListenerTimeout(d time.Duration)
+}
+
+func (e *emitter) SetListenerTimeout(d time.Duration) {
+	e.timeout.Store(d)
+}
+
+func (e *emitter) listenerTimeout() time.Duration {
+	return e.timeout.Load().(time.Duration)
+}
+
+// listenersMatching returns the listeners of m whose event matches event:
+// the ones registered for event itself when m.matchEvent is nil, or else
+// those of every registered event that m.matchEvent matches with event.
+func listenersMatching(m eventManager, event string) (listeners []Listener) {
+	if m.matchEvent == nil {
+		ls := m.events[event]
+		listeners = make([]Listener, len(ls))
+		for i := range ls {
+			listeners[i] = ls[i].Listener
+		}
+		return
+	}
+
+	for matchedEvent, ls := range m.events {
+		if m.matchEvent(matchedEvent, event) {
+			for i := range ls {
+				listeners = append(listeners, ls[i].Listener)
+			}
+		}
+	}
+	return
+}
+
+// callWithTimeout calls listener.Callback, but stops waiting for it once d
+// elapses, leaving the listener to finish on its own goroutine.
+//
+// A panic from the listener is recovered inside that goroutine and reported
+// through onPanic instead of crashing the process, since by the time it
+// happens the caller of callWithTimeout may already have stopped waiting.
+func callWithTimeout(d time.Duration, onPanic func(event, listenerName string, recovered interface{}),
+	listener Listener, event string, data ...interface{}) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				onPanic(event, listener.Name(), r)
+			}
+		}()
+		listener.Callback(event, data...)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(d):
+	}
+}
+
+// EmitContext fires event synchronously, in the order the listeners were
+// registered, but stops invoking further listeners and returns ctx.Err()
+// as soon as ctx is done.
+func (e *emitter) EmitContext(ctx context.Context, event string, data ...interface{}) error {
+	timeout := e.listenerTimeout()
+	for _, listener := range e.chainedListeners(event) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		e.callListener(timeout, listener, event, data...)
+	}
+	return ctx.Err()
+}
+
+type contextResult struct {
+	wg   sync.WaitGroup
+	done chan struct{}
+	once sync.Once
+	pnc  interface{}
+}
+
+func (r *contextResult) Wait() { r.wg.Wait() }
+
+func (r *contextResult) WaitErr() interface{} {
+	r.wg.Wait()
+	return r.pnc
+}
+
+func (r *contextResult) recoverPanic(v interface{}) {
+	r.once.Do(func() { r.pnc = v })
+}
+
+func (r *contextResult) WaitContext(ctx context.Context) error {
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitContext is the same as Wait, but returns ctx.Err() instead of
+// blocking any further once ctx is done.
+func (r *asyncResult) WaitContext(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// EmitAsyncContext is the same as EmitAsync, but passes ctx to every
+// listener that implements ContextListener; the others are called as
+// usual, ignoring ctx.
+func (e *emitter) EmitAsyncContext(ctx context.Context, event string, data ...interface{}) Result {
+	listeners := listenersMatching(e.loadEvents(), event)
+
+	e.lock.RLock()
+	mw := e.middlewares
+	e.lock.RUnlock()
+	for i, ln := range listeners {
+		listeners[i] = Chain(contextListener(ctx, ln), mw...)
+	}
+
+	result := &contextResult{done: make(chan struct{})}
+	result.wg.Add(len(listeners))
+	for _, listener := range listeners {
+		go e.emitAsyncContext(ctx, result, listener, event, data...)
+	}
+	go func() {
+		result.wg.Wait()
+		close(result.done)
+	}()
+
+	return result
+}
+
+func (e *emitter) emitAsyncContext(ctx context.Context, result *contextResult,
+	listener Listener, event string, data ...interface{}) {
+	defer e.asyncContextDone(result, event, listener.Name())
+	if cl, ok := listener.(ContextListener); ok {
+		cl.CallbackContext(ctx, event, data...)
+		return
+	}
+	listener.Callback(event, data...)
+}
+
+func (e *emitter) asyncContextDone(result *contextResult, evt, ln string) {
+	defer result.wg.Done()
+	if r := recover(); r != nil {
+		result.recoverPanic(r)
+		e.handleError(evt, ln, r)
+	}
+}
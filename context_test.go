@@ -0,0 +1,149 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type ctxListener struct {
+	called chan context.Context
+}
+
+func (l *ctxListener) Name() string { return "ctxListener" }
+func (l *ctxListener) Callback(event string, data ...interface{}) {
+	panic("CallbackContext should have been called instead")
+}
+func (l *ctxListener) CallbackContext(ctx context.Context, event string, data ...interface{}) error {
+	l.called <- ctx
+	return nil
+}
+
+func TestEmitContextStopsOnCancel(t *testing.T) {
+	var called []string
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := NewCommon(nil).(ContextEmitter)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		called = append(called, "ln1")
+		cancel()
+	}))
+	e.On("e1", NewListener("ln2", func(event string, data ...interface{}) {
+		called = append(called, "ln2")
+	}))
+
+	err := e.EmitContext(ctx, "e1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled, got %v", err)
+	}
+	if len(called) != 1 || called[0] != "ln1" {
+		t.Errorf("expect only ln1 to run before the context was cancelled, got %v", called)
+	}
+}
+
+func TestEmitAsyncContextDispatchesToContextListener(t *testing.T) {
+	ln := &ctxListener{called: make(chan context.Context, 1)}
+
+	e := NewCommon(nil).(ContextEmitter)
+	e.On("e1", ln)
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "v")
+	e.EmitAsyncContext(ctx, "e1").Wait()
+
+	select {
+	case got := <-ln.called:
+		if got != ctx {
+			t.Error("expect the ContextListener to receive the emitted context")
+		}
+	default:
+		t.Error("expect the ContextListener to have been called")
+	}
+}
+
+func TestContextResultWaitContextTimesOut(t *testing.T) {
+	block := make(chan struct{})
+	e := NewCommon(nil).(ContextEmitter)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) { <-block }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	result := e.EmitAsyncContext(context.Background(), "e1").(ContextResult)
+	if err := result.WaitContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expect context.DeadlineExceeded, got %v", err)
+	}
+	close(block)
+}
+
+func TestEmitAsyncContextWaitErr(t *testing.T) {
+	e := NewCommon(nil).(ContextEmitter)
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+
+	result := e.EmitAsyncContext(context.Background(), "e1")
+	if p := result.WaitErr(); p != "boom" {
+		t.Errorf("expect WaitErr to return the recovered panic %q, got %v", "boom", p)
+	}
+}
+
+func TestListenerTimeoutDoesNotStallEmit(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	e := NewCommon(nil).(TimeoutEmitter)
+	e.SetListenerTimeout(time.Millisecond)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) { <-block }))
+
+	done := make(chan struct{})
+	go func() {
+		e.Emit("e1")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit did not return within the listener timeout")
+	}
+}
+
+func TestListenerTimeoutRecoversPanickingListener(t *testing.T) {
+	handled := make(chan interface{}, 1)
+
+	e := NewCommon(nil).(interface {
+		TimeoutEmitter
+		ErrorHandlingEmitter
+	})
+	e.SetListenerTimeout(time.Millisecond)
+	e.SetErrorHandler(func(event, listenerName string, recovered interface{}) {
+		handled <- recovered
+	})
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		panic("boom")
+	}))
+
+	e.Emit("e1")
+
+	select {
+	case r := <-handled:
+		if r != "boom" {
+			t.Errorf("expect the error handler to observe the panic, got %v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expect the error handler to observe the panic from the timed-out listener")
+	}
+}
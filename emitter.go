@@ -50,6 +50,10 @@ type Emitter interface {
 type Result interface {
 	// Wait doesn't return until all listeners have been called.
 	Wait()
+
+	// WaitErr is the same as Wait, but also returns the value recovered
+	// from the first listener that panicked, or nil if none did.
+	WaitErr() (firstPanic interface{})
 }
 
 // Listener is used to listen the event and called when the event is emitted.
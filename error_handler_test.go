@@ -0,0 +1,74 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import "testing"
+
+func TestEmitRecoversPanickingListener(t *testing.T) {
+	var gotEvent, gotListener string
+	var gotRecovered interface{}
+
+	e := NewCommon(nil).(ErrorHandlingEmitter)
+	e.SetErrorHandler(func(event, listenerName string, recovered interface{}) {
+		gotEvent, gotListener, gotRecovered = event, listenerName, recovered
+	})
+
+	called := false
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+	e.On("e1", NewListener("ln2", func(string, ...interface{}) { called = true }))
+	e.Emit("e1")
+
+	if gotEvent != "e1" || gotListener != "panicker" || gotRecovered != "boom" {
+		t.Errorf("expect the error handler to observe the panic, got event=%q listener=%q recovered=%v",
+			gotEvent, gotListener, gotRecovered)
+	}
+	if !called {
+		t.Error("expect the listener after the panicking one to still run")
+	}
+}
+
+func TestEmitAsyncRecoversPanickingListener(t *testing.T) {
+	var gotListener string
+
+	e := NewCommon(nil).(ErrorHandlingEmitter)
+	e.SetErrorHandler(func(event, listenerName string, recovered interface{}) {
+		gotListener = listenerName
+	})
+
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+	e.EmitAsync("e1").Wait()
+
+	if gotListener != "panicker" {
+		t.Errorf("expect the error handler to observe the panic, got listener=%q", gotListener)
+	}
+}
+
+func TestEmitAsyncWaitErr(t *testing.T) {
+	e := NewCommon(nil)
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+
+	if p := e.EmitAsync("e1").WaitErr(); p != "boom" {
+		t.Errorf("expect WaitErr to return the recovered panic %q, got %v", "boom", p)
+	}
+}
+
+func TestEmitAsyncWaitErrNoPanic(t *testing.T) {
+	e := NewCommon(nil)
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) {}))
+
+	if p := e.EmitAsync("e1").WaitErr(); p != nil {
+		t.Errorf("expect WaitErr to return nil when no listener panicked, got %v", p)
+	}
+}
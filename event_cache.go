@@ -0,0 +1,170 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"log"
+	"sync"
+)
+
+type cachedEvent struct {
+	name string
+	data []interface{}
+}
+
+// EventCacheOption is used to configure an EventCache when it's created.
+type EventCacheOption func(*EventCache)
+
+// WithMaxBuffered sets the maximum number of the events that EventCache
+// is allowed to buffer.
+//
+// Once the buffer reaches maxn, FireEvent discards the new event instead
+// of buffering it, and the number returned by Dropped is increased by one.
+// This protects a leaking caller, i.e. one that fires events but never
+// flushes them, from exhausting memory.
+//
+// The default is 0, which means no limit.
+func WithMaxBuffered(maxn int) EventCacheOption {
+	return func(c *EventCache) { c.maxn = maxn }
+}
+
+// EventCache wraps an Emitter and defers the actual delivery of the fired
+// events until Flush is called.
+//
+// It's useful when a component wants to collect a burst of state-transition
+// events and only publish them after some atomic operation, e.g. a database
+// commit, succeeds.
+type EventCache struct {
+	emitter Emitter
+	maxn    int
+
+	lock    sync.Mutex
+	events  []cachedEvent
+	dropped uint64
+}
+
+// NewEventCache returns a new EventCache that buffers the fired events
+// and flushes them to e.
+func NewEventCache(e Emitter, options ...EventCacheOption) *EventCache {
+	c := &EventCache{emitter: e}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// FireEvent buffers event and data, but does not dispatch them to the
+// listeners registered on the wrapped Emitter until Flush is called.
+//
+// It's safe for concurrent use by multiple goroutines.
+func (c *EventCache) FireEvent(event string, data ...interface{}) {
+	c.lock.Lock()
+	if c.maxn > 0 && len(c.events) >= c.maxn {
+		c.dropped++
+		c.lock.Unlock()
+		return
+	}
+	c.events = append(c.events, cachedEvent{name: event, data: data})
+	c.lock.Unlock()
+}
+
+// Flush dispatches all the currently buffered events to the wrapped Emitter,
+// in the order they were fired, then empties the buffer.
+//
+// The buffer is swapped out under lock before iterating, so FireEvent calls
+// made while Flush is running don't block and are buffered for the next
+// Flush. A listener panicking during the dispatch of one buffered event is
+// recovered and logged so that the remaining buffered events are still
+// flushed.
+func (c *EventCache) Flush() {
+	for _, e := range c.swap() {
+		c.flushOne(e)
+	}
+}
+
+func (c *EventCache) flushOne(e cachedEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("EventEmitter: EventCache flush panics on event '%s': %v", e.name, r)
+		}
+	}()
+	c.emitter.Emit(e.name, e.data...)
+}
+
+// FlushAsync is the same as Flush, but triggers the listeners of the
+// buffered events asynchronously, which mirrors Emitter.EmitAsync.
+func (c *EventCache) FlushAsync() Result {
+	events := c.swap()
+	results := make(multiResult, len(events))
+	for i, e := range events {
+		results[i] = c.emitter.EmitAsync(e.name, e.data...)
+	}
+	return results
+}
+
+// Len returns the number of the currently buffered events.
+func (c *EventCache) Len() int {
+	c.lock.Lock()
+	n := len(c.events)
+	c.lock.Unlock()
+	return n
+}
+
+// Dropped returns the number of the events that have been discarded
+// because the buffer had reached the maximum size set by WithMaxBuffered.
+func (c *EventCache) Dropped() uint64 {
+	c.lock.Lock()
+	n := c.dropped
+	c.lock.Unlock()
+	return n
+}
+
+// Reset discards all the currently buffered events without dispatching
+// them to any listener.
+func (c *EventCache) Reset() {
+	c.lock.Lock()
+	c.events = nil
+	c.lock.Unlock()
+}
+
+func (c *EventCache) swap() []cachedEvent {
+	c.lock.Lock()
+	events := c.events
+	c.events = nil
+	c.lock.Unlock()
+	return events
+}
+
+// multiResult waits for a batch of independent Results, such as the ones
+// returned by FlushAsync.
+type multiResult []Result
+
+func (rs multiResult) Wait() {
+	for _, r := range rs {
+		r.Wait()
+	}
+}
+
+// WaitErr waits for every Result in rs, same as Wait, but returns the value
+// recovered from the first listener that panicked across all of them, or
+// nil if none did.
+func (rs multiResult) WaitErr() (firstPanic interface{}) {
+	for _, r := range rs {
+		if p := r.WaitErr(); p != nil && firstPanic == nil {
+			firstPanic = p
+		}
+	}
+	return
+}
@@ -0,0 +1,204 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func ExampleEventCache() {
+	var fired []string
+	e := New()
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		fired = append(fired, fmt.Sprint(data...))
+	}))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1", "a")
+	c.FireEvent("e1", "b")
+	c.FireEvent("e1", "c")
+	fmt.Println("before flush:", fired)
+
+	c.Flush()
+	fmt.Println("after flush:", fired)
+
+	// Output:
+	// before flush: []
+	// after flush: [a b c]
+}
+
+func ExampleEventCache_reset() {
+	emitted := 0
+	e := New()
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) { emitted++ }))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1")
+	c.FireEvent("e1")
+	c.Reset()
+	c.Flush()
+
+	fmt.Println(emitted)
+	// Output:
+	// 0
+}
+
+func TestEventCacheOrdering(t *testing.T) {
+	var order []interface{}
+	e := NewCommon(nil)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		order = append(order, data[0])
+	}))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1", 1)
+	c.FireEvent("e1", 2)
+	c.FireEvent("e1", 3)
+
+	if n := c.Len(); n != 3 {
+		t.Fatalf("expect 3 buffered events, but got %d", n)
+	}
+
+	c.Flush()
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Errorf("expect the events to be flushed in FIFO order, got %v", order)
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("expect the buffer to be emptied after Flush, but got %d buffered", n)
+	}
+}
+
+func TestEventCacheResetDiscards(t *testing.T) {
+	emitted := false
+	e := NewCommon(nil)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) { emitted = true }))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1")
+	c.Reset()
+	c.Flush()
+
+	if emitted {
+		t.Error("expect Reset to discard the buffered events without emitting them")
+	}
+}
+
+func TestEventCachePanicDoesNotLoseRemainingEvents(t *testing.T) {
+	var got []interface{}
+	e := NewCommon(nil)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		if data[0] == 2 {
+			panic("boom")
+		}
+		got = append(got, data[0])
+	}))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1", 1)
+	c.FireEvent("e1", 2) // this one panics
+	c.FireEvent("e1", 3)
+	c.Flush()
+
+	if len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("expect the events around the panicking one to still be flushed, got %v", got)
+	}
+}
+
+func TestEventCacheWildcardMatch(t *testing.T) {
+	var matched []string
+	matchEvent := func(matchedEvent, emittedEvent string) bool {
+		return strings.HasSuffix(matchedEvent, "*") &&
+			strings.HasPrefix(emittedEvent, matchedEvent[:len(matchedEvent)-1])
+	}
+
+	e := NewCommon(matchEvent)
+	e.On("order.*", NewListener("ln1", func(event string, data ...interface{}) {
+		matched = append(matched, event)
+	}))
+
+	c := NewEventCache(e)
+	c.FireEvent("order.created")
+	c.FireEvent("order.paid")
+	c.Flush()
+
+	if len(matched) != 2 || matched[0] != "order.created" || matched[1] != "order.paid" {
+		t.Errorf("expect both wildcard-matched events to be flushed in order, got %v", matched)
+	}
+}
+
+func TestEventCacheMaxBuffered(t *testing.T) {
+	e := New()
+	c := NewEventCache(e, WithMaxBuffered(2))
+
+	c.FireEvent("e1")
+	c.FireEvent("e1")
+	c.FireEvent("e1")
+
+	if n := c.Len(); n != 2 {
+		t.Errorf("expect 2 buffered events, but got %d", n)
+	}
+	if n := c.Dropped(); n != 1 {
+		t.Errorf("expect 1 dropped event, but got %d", n)
+	}
+}
+
+func TestEventCacheFlushAsyncWaitErr(t *testing.T) {
+	e := NewCommon(nil)
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) {}))
+	e.On("e2", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+
+	c := NewEventCache(e)
+	c.FireEvent("e1")
+	c.FireEvent("e2")
+
+	if p := c.FlushAsync().WaitErr(); p != "boom" {
+		t.Errorf("expect WaitErr to return the recovered panic %q, got %v", "boom", p)
+	}
+}
+
+func TestEventCacheConcurrentProducers(t *testing.T) {
+	const producers = 20
+	const perProducer = 50
+
+	var lock sync.Mutex
+	var count int
+	e := New()
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		lock.Lock()
+		count++
+		lock.Unlock()
+	}))
+
+	c := NewEventCache(e)
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for i := 0; i < producers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				c.FireEvent("e1", j)
+			}
+		}()
+	}
+	wg.Wait()
+	c.Flush()
+
+	if total := producers * perProducer; count != total {
+		t.Errorf("expect %d emitted events, but got %d", total, count)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type indexListener struct {
@@ -54,56 +55,12 @@ func (m eventManager) Listeners(event string) (listeners []Listener) {
 	return
 }
 
-func (m eventManager) Emit(event string, data ...interface{}) {
-	if m.matchEvent == nil {
-		for _, listener := range m.events[event] {
-			listener.Callback(event, data...)
-		}
-	} else {
-		for matchedEvent, listeners := range m.events {
-			if m.matchEvent(matchedEvent, event) {
-				for _, listener := range listeners {
-					listener.Callback(event, data...)
-				}
-			}
-		}
-	}
-}
-
-func (m eventManager) EmitAsync(event string, data ...interface{}) Result {
-	wg := new(sync.WaitGroup)
-
-	if m.matchEvent == nil {
-		listeners := m.events[event]
-		wg.Add(len(listeners))
-		for _, listener := range listeners {
-			go m.emitAsync(wg, listener, event, data...)
-		}
-	} else {
-		for matchedEvent, listeners := range m.events {
-			if m.matchEvent(matchedEvent, event) {
-				wg.Add(len(listeners))
-				for _, listener := range listeners {
-					go m.emitAsync(wg, listener, event, data...)
-				}
-			}
-		}
-	}
+// ErrorHandler is called instead of letting the panic from a listener
+// propagate, whenever Emit or EmitAsync recovers one.
+type ErrorHandler func(event, listenerName string, recovered interface{})
 
-	return wg
-}
-
-func (m eventManager) emitAsync(wg *sync.WaitGroup, listener Listener,
-	event string, data ...interface{}) {
-	defer m.asyncDone(wg, event, listener.Name())
-	listener.Callback(event, data...)
-}
-
-func (m eventManager) asyncDone(wg *sync.WaitGroup, evt string, ln string) {
-	wg.Done()
-	if err := recover(); err != nil {
-		log.Printf("EventEmitter: listener '%s' panics on event '%s'", evt, ln)
-	}
+func defaultErrorHandler(event, listenerName string, recovered interface{}) {
+	log.Printf("EventEmitter: listener '%s' panics on event '%s': %v", listenerName, event, recovered)
 }
 
 type emitter struct {
@@ -113,6 +70,13 @@ type emitter struct {
 	evtm map[string]map[string]indexListener
 	evtv atomic.Value
 	eidx uint64
+
+	errh     atomic.Value // ErrorHandler
+	typeErrh atomic.Value // TypeErrorHandler
+
+	timeout atomic.Value // time.Duration
+
+	middlewares []Middleware
 }
 
 // New returns a new thread-safe event emitter.
@@ -126,6 +90,9 @@ func NewCommon(matchEvent func(matchedEvent, emittedEvent string) bool) Emitter
 	}
 
 	e.storeEvents(eventManager{})
+	e.timeout.Store(time.Duration(0))
+	e.errh.Store(ErrorHandler(defaultErrorHandler))
+	e.typeErrh.Store(TypeErrorHandler(defaultTypeErrorHandler))
 	return e
 }
 
@@ -154,12 +121,105 @@ func (e *emitter) Listeners(event string) []Listener {
 	return e.loadEvents().Listeners(event)
 }
 
+// ErrorHandlingEmitter is implemented by the Emitters, such as the ones
+// returned by New and NewCommon, that let the caller replace how a
+// panicking listener is reported.
+type ErrorHandlingEmitter interface {
+	Emitter
+
+	// SetErrorHandler replaces the handler called whenever Emit or
+	// EmitAsync recovers a panicking listener. A nil handler restores the
+	// default, which logs the panic.
+	SetErrorHandler(handler ErrorHandler)
+}
+
+func (e *emitter) SetErrorHandler(handler ErrorHandler) {
+	if handler == nil {
+		handler = defaultErrorHandler
+	}
+	e.errh.Store(handler)
+}
+
+func (e *emitter) errorHandler() ErrorHandler {
+	return e.errh.Load().(ErrorHandler)
+}
+
+// handleError reports a recovered panic through errorHandler, swallowing a
+// panic from the handler itself so that a single bad report can't crash the
+// process.
+func (e *emitter) handleError(event, listenerName string, recovered interface{}) {
+	defer func() { recover() }()
+	e.errorHandler()(event, listenerName, recovered)
+}
+
+// Emit fires event synchronously, recovering and reporting a panic from any
+// listener through the ErrorHandler instead of letting it propagate.
 func (e *emitter) Emit(event string, data ...interface{}) {
-	e.loadEvents().Emit(event, data...)
+	timeout := e.listenerTimeout()
+	for _, listener := range e.chainedListeners(event) {
+		e.callListener(timeout, listener, event, data...)
+	}
+}
+
+func (e *emitter) callListener(timeout time.Duration, listener Listener, event string, data ...interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.handleError(event, listener.Name(), r)
+		}
+	}()
+
+	if timeout > 0 {
+		callWithTimeout(timeout, e.handleError, listener, event, data...)
+	} else {
+		listener.Callback(event, data...)
+	}
+}
+
+// asyncResult is the Result returned by EmitAsync, and also tracks the
+// value recovered from the first listener that panicked, if any, so that
+// WaitErr can report it.
+type asyncResult struct {
+	wg   sync.WaitGroup
+	once sync.Once
+	pnc  interface{}
 }
 
+func (r *asyncResult) Wait() { r.wg.Wait() }
+
+func (r *asyncResult) WaitErr() interface{} {
+	r.wg.Wait()
+	return r.pnc
+}
+
+func (r *asyncResult) recoverPanic(v interface{}) {
+	r.once.Do(func() { r.pnc = v })
+}
+
+// EmitAsync is the same as Emit, but triggers the matched listeners in their
+// own goroutine, recovering and reporting a panic from any of them through
+// the ErrorHandler.
 func (e *emitter) EmitAsync(event string, data ...interface{}) Result {
-	return e.loadEvents().EmitAsync(event, data...)
+	listeners := e.chainedListeners(event)
+
+	result := new(asyncResult)
+	result.wg.Add(len(listeners))
+	for _, listener := range listeners {
+		go e.emitAsync(result, listener, event, data...)
+	}
+	return result
+}
+
+func (e *emitter) emitAsync(result *asyncResult, listener Listener, event string, data ...interface{}) {
+	defer e.asyncDone(result, event, listener.Name())
+	listener.Callback(event, data...)
+}
+
+func (e *emitter) asyncDone(result *asyncResult, event, listenerName string) {
+	defer result.wg.Done()
+	if r := recover(); r != nil {
+		result.recoverPanic(r)
+		e.handleError(event, listenerName, r)
+	}
 }
 
 func (e *emitter) On(event string, listener Listener) {
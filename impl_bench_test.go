@@ -27,7 +27,7 @@ func BenchmarkNewCommon(b *testing.B) {
 }
 
 func benchmarkEmitter(b *testing.B, emitter Emitter) {
-	emitter.On("event", "ln", ListenerFunc(func(string, ...interface{}) {}))
+	emitter.On("event", NewListener("ln", func(string, ...interface{}) {}))
 
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -21,7 +21,7 @@ import (
 )
 
 func ExampleNew() {
-	newListener := func(listenerName string) ListenerFunc {
+	newListener := func(listenerName string) Callback {
 		return func(event string, data ...interface{}) {
 			fmt.Printf("listener=%s, event=%s, data=%v\n", listenerName, event, data)
 		}
@@ -31,8 +31,8 @@ func ExampleNew() {
 	ln2 := newListener("ln2")
 	ln3 := newListener("ln3")
 
-	On("e1", "ln1", ln1)
-	On("e1", "ln2", ln2)
+	On("e1", NewListener("ln1", ln1))
+	On("e1", NewListener("ln2", ln2))
 	OnFunc("e2", "ln2", ln2)
 	OnFunc("e2", "ln3", ln3)
 	OnFunc("e3", "ln3", ln3)
@@ -116,17 +116,17 @@ func ExampleNewCommon() {
 		return matchedEvent == emittedEvent
 	}
 
-	newListener := func(listenerName string) ListenerFunc {
+	newListener := func(listenerName string) Callback {
 		return func(event string, data ...interface{}) {
 			fmt.Printf("listener=%s, event=%s, data=%v\n", listenerName, event, data)
 		}
 	}
 
 	emitter := NewCommon(matchEvent)
-	emitter.On("*", "ln1", newListener("ln1"))
-	emitter.On("*.suffix", "ln2", newListener("ln2"))
-	emitter.On("prefix.*", "ln3", newListener("ln3"))
-	emitter.On("exact", "ln4", newListener("ln4"))
+	emitter.On("*", NewListener("ln1", newListener("ln1")))
+	emitter.On("*.suffix", NewListener("ln2", newListener("ln2")))
+	emitter.On("prefix.*", NewListener("ln3", newListener("ln3")))
+	emitter.On("exact", NewListener("ln4", newListener("ln4")))
 
 	events := emitter.Events()
 	sort.Strings(events)
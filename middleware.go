@@ -0,0 +1,123 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import "time"
+
+// Middleware wraps a Listener with a cross-cutting concern, such as
+// logging, metrics, panic recovery or filtering, without having to modify
+// every listener.
+type Middleware func(next Listener) Listener
+
+// Chain wraps ln with mw, applying mw[0] outermost, so mw[0] observes the
+// event first and mw[len(mw)-1] is the closest to ln.
+func Chain(ln Listener, mw ...Middleware) Listener {
+	for i := len(mw) - 1; i >= 0; i-- {
+		ln = mw[i](ln)
+	}
+	return ln
+}
+
+// MiddlewareEmitter is implemented by the Emitters, such as the ones
+// returned by New and NewCommon, that support wrapping every listener call
+// with a middleware chain.
+type MiddlewareEmitter interface {
+	Emitter
+
+	// Use appends mw to the middleware chain applied to every listener
+	// call made by Emit or EmitAsync, in registration order.
+	Use(mw ...Middleware)
+}
+
+func (e *emitter) Use(mw ...Middleware) {
+	e.lock.Lock()
+	e.middlewares = append(e.middlewares, mw...)
+	e.lock.Unlock()
+}
+
+func (e *emitter) hasMiddlewares() bool {
+	e.lock.RLock()
+	has := len(e.middlewares) > 0
+	e.lock.RUnlock()
+	return has
+}
+
+// chainedListeners resolves the listeners matching event, wrapping each of
+// them through the registered middleware chain.
+func (e *emitter) chainedListeners(event string) []Listener {
+	listeners := listenersMatching(e.loadEvents(), event)
+
+	e.lock.RLock()
+	mw := e.middlewares
+	e.lock.RUnlock()
+	if len(mw) == 0 {
+		return listeners
+	}
+
+	chained := make([]Listener, len(listeners))
+	for i, ln := range listeners {
+		chained[i] = Chain(ln, mw...)
+	}
+	return chained
+}
+
+// funcListener adapts a name and a callback function to Listener, which is
+// how the built-in middlewares wrap the listener they're given.
+type funcListener struct {
+	name string
+	cb   func(event string, data ...interface{})
+}
+
+func (l funcListener) Name() string                               { return l.name }
+func (l funcListener) Callback(event string, data ...interface{}) { l.cb(event, data...) }
+
+// RecoverMiddleware returns a Middleware that recovers a panicking listener
+// and reports it to handler instead of letting it propagate.
+func RecoverMiddleware(handler func(event, listenerName string, recovered interface{})) Middleware {
+	return func(next Listener) Listener {
+		return funcListener{name: next.Name(), cb: func(event string, data ...interface{}) {
+			defer func() {
+				if r := recover(); r != nil {
+					handler(event, next.Name(), r)
+				}
+			}()
+			next.Callback(event, data...)
+		}}
+	}
+}
+
+// TimingMiddleware returns a Middleware that reports how long each listener
+// call takes to handler.
+func TimingMiddleware(handler func(event, listenerName string, d time.Duration)) Middleware {
+	return func(next Listener) Listener {
+		return funcListener{name: next.Name(), cb: func(event string, data ...interface{}) {
+			start := time.Now()
+			next.Callback(event, data...)
+			handler(event, next.Name(), time.Since(start))
+		}}
+	}
+}
+
+// FilterMiddleware returns a Middleware that only forwards the event to
+// next when predicate returns true.
+func FilterMiddleware(predicate func(event string, data ...interface{}) bool) Middleware {
+	return func(next Listener) Listener {
+		return funcListener{name: next.Name(), cb: func(event string, data ...interface{}) {
+			if predicate(event, data...) {
+				next.Callback(event, data...)
+			}
+		}}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleChain() {
+	trace := func(name string) Middleware {
+		return func(next Listener) Listener {
+			return funcListener{name: next.Name(), cb: func(event string, data ...interface{}) {
+				fmt.Printf("enter %s\n", name)
+				next.Callback(event, data...)
+				fmt.Printf("leave %s\n", name)
+			}}
+		}
+	}
+
+	ln := NewListener("ln1", func(event string, data ...interface{}) {
+		fmt.Println("listener")
+	})
+
+	Chain(ln, trace("outer"), trace("inner")).Callback("e1")
+
+	// Output:
+	// enter outer
+	// enter inner
+	// listener
+	// leave inner
+	// leave outer
+}
+
+func TestUseRecoverMiddleware(t *testing.T) {
+	var gotEvent, gotListener string
+	var gotRecovered interface{}
+
+	e := NewCommon(nil).(MiddlewareEmitter)
+	e.Use(RecoverMiddleware(func(event, listenerName string, recovered interface{}) {
+		gotEvent, gotListener, gotRecovered = event, listenerName, recovered
+	}))
+
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+	e.Emit("e1")
+
+	if gotEvent != "e1" || gotListener != "panicker" || gotRecovered != "boom" {
+		t.Errorf("expect the recover middleware to observe the panic, got event=%q listener=%q recovered=%v",
+			gotEvent, gotListener, gotRecovered)
+	}
+}
+
+func TestUseTimingMiddleware(t *testing.T) {
+	var gotDuration time.Duration
+
+	e := NewCommon(nil).(MiddlewareEmitter)
+	e.Use(TimingMiddleware(func(event, listenerName string, d time.Duration) {
+		gotDuration = d
+	}))
+
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) {
+		time.Sleep(time.Millisecond)
+	}))
+	e.Emit("e1")
+
+	if gotDuration < time.Millisecond {
+		t.Errorf("expect the timing middleware to observe at least 1ms, got %s", gotDuration)
+	}
+}
+
+func TestUseFilterMiddleware(t *testing.T) {
+	called := false
+
+	e := NewCommon(nil).(MiddlewareEmitter)
+	e.Use(FilterMiddleware(func(event string, data ...interface{}) bool {
+		return len(data) > 0 && data[0] == "allowed"
+	}))
+
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	e.Emit("e1", "blocked")
+	if called {
+		t.Fatal("expect the filter middleware to drop the blocked event")
+	}
+
+	e.Emit("e1", "allowed")
+	if !called {
+		t.Error("expect the filter middleware to let the allowed event through")
+	}
+}
+
+func TestUseAppliesToEmitAsync(t *testing.T) {
+	called := false
+
+	e := NewCommon(nil).(MiddlewareEmitter)
+	e.Use(FilterMiddleware(func(string, ...interface{}) bool { return false }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	e.EmitAsync("e1").Wait()
+	if called {
+		t.Error("expect the middleware chain to also apply to EmitAsync")
+	}
+}
+
+func TestUseAppliesToEmitContext(t *testing.T) {
+	called := false
+
+	e := NewCommon(nil).(interface {
+		MiddlewareEmitter
+		ContextEmitter
+	})
+	e.Use(FilterMiddleware(func(string, ...interface{}) bool { return false }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	if err := e.EmitContext(context.Background(), "e1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Error("expect the middleware chain to also apply to EmitContext")
+	}
+}
+
+func TestUseAppliesToEmitAsyncContext(t *testing.T) {
+	called := false
+
+	e := NewCommon(nil).(interface {
+		MiddlewareEmitter
+		ContextEmitter
+	})
+	e.Use(FilterMiddleware(func(string, ...interface{}) bool { return false }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	e.EmitAsyncContext(context.Background(), "e1").Wait()
+	if called {
+		t.Error("expect the middleware chain to also apply to EmitAsyncContext")
+	}
+}
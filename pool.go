@@ -0,0 +1,229 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Policy controls what a pool-backed Emitter does with a job for EmitAsync
+// when the job queue is full.
+type Policy int
+
+const (
+	// PolicyBlock blocks the caller of EmitAsync until there's room in the
+	// queue. It's the default.
+	PolicyBlock Policy = iota
+
+	// PolicyDropNewest discards the job instead of queueing it, so that a
+	// slow consumer can't block the caller of EmitAsync.
+	PolicyDropNewest
+
+	// PolicyRunInline falls back to calling the listener synchronously on
+	// the goroutine that called EmitAsync.
+	PolicyRunInline
+)
+
+// PoolEmitter is implemented by the Emitter returned by NewWithPool, and
+// lets the caller choose the queue-full policy.
+type PoolEmitter interface {
+	Emitter
+
+	// SetPolicy sets the policy applied by EmitAsync when the job queue is
+	// full. The default is PolicyBlock.
+	SetPolicy(Policy)
+}
+
+type poolJob struct {
+	listener Listener
+	event    string
+	data     []interface{}
+	result   *asyncResult
+}
+
+type poolEmitter struct {
+	*emitter
+
+	lock   sync.RWMutex
+	policy Policy
+	jobs   chan poolJob
+}
+
+// NewWithPool returns a new Emitter whose EmitAsync dispatches the matched
+// listeners onto a fixed-size worker pool of workers goroutines reading
+// from a queue buffering up to queueSize jobs, instead of spawning one
+// goroutine per listener per Emit like New and NewCommon do.
+//
+// This avoids the goroutine explosion that a burst of high-frequency events
+// with many listeners can otherwise cause.
+func NewWithPool(matchEvent func(matchedEvent, emittedEvent string) bool, workers, queueSize int) Emitter {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	e := &poolEmitter{
+		emitter: &emitter{
+			matchEvent: matchEvent,
+			evtm:       make(map[string]map[string]indexListener, 16),
+		},
+		jobs: make(chan poolJob, queueSize),
+	}
+	e.storeEvents(eventManager{})
+	e.timeout.Store(time.Duration(0))
+	e.errh.Store(ErrorHandler(defaultErrorHandler))
+	e.typeErrh.Store(TypeErrorHandler(defaultTypeErrorHandler))
+
+	for i := 0; i < workers; i++ {
+		go e.worker()
+	}
+
+	return e
+}
+
+func (e *poolEmitter) SetPolicy(p Policy) {
+	e.lock.Lock()
+	e.policy = p
+	e.lock.Unlock()
+}
+
+func (e *poolEmitter) getPolicy() Policy {
+	e.lock.RLock()
+	p := e.policy
+	e.lock.RUnlock()
+	return p
+}
+
+// EmitAsync is the same as Emit, but queues one job per matched listener
+// onto the worker pool instead of spawning a goroutine, and returns a
+// Result whose Wait blocks until all of those jobs have run.
+func (e *poolEmitter) EmitAsync(event string, data ...interface{}) Result {
+	listeners := e.chainedListeners(event)
+
+	result := new(asyncResult)
+	result.wg.Add(len(listeners))
+	for _, listener := range listeners {
+		e.submit(poolJob{listener: listener, event: event, data: data, result: result})
+	}
+	return result
+}
+
+// EmitContext is the same as Emit, but runs each matched listener through
+// the worker pool instead of the caller's own goroutine, and stops
+// invoking further listeners and returns ctx.Err() as soon as ctx is done.
+//
+// The queue-full policy applies to these jobs too: under PolicyRunInline
+// the listener runs on the caller's goroutine, and under PolicyDropNewest
+// it's skipped.
+func (e *poolEmitter) EmitContext(ctx context.Context, event string, data ...interface{}) error {
+	timeout := e.listenerTimeout()
+	for _, listener := range e.chainedListeners(event) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if timeout > 0 {
+			ln := listener
+			listener = funcListener{name: ln.Name(), cb: func(event string, data ...interface{}) {
+				callWithTimeout(timeout, e.handleError, ln, event, data...)
+			}}
+		}
+		e.runSync(listener, event, data...)
+	}
+	return ctx.Err()
+}
+
+// runSync submits a job for listener to the worker pool, under the
+// queue-full policy, and blocks until the job has run or has been dropped.
+func (e *poolEmitter) runSync(listener Listener, event string, data ...interface{}) {
+	result := new(asyncResult)
+	result.wg.Add(1)
+	e.submit(poolJob{listener: listener, event: event, data: data, result: result})
+	result.wg.Wait()
+}
+
+// EmitAsyncContext is the same as EmitAsync, but passes ctx to every
+// listener that implements ContextListener; the others are called as
+// usual, ignoring ctx. Like EmitAsync, it dispatches through the worker
+// pool instead of spawning one goroutine per listener.
+func (e *poolEmitter) EmitAsyncContext(ctx context.Context, event string, data ...interface{}) Result {
+	listeners := listenersMatching(e.loadEvents(), event)
+
+	e.emitter.lock.RLock()
+	mw := e.middlewares
+	e.emitter.lock.RUnlock()
+
+	result := new(asyncResult)
+	result.wg.Add(len(listeners))
+	for _, listener := range listeners {
+		listener = Chain(contextListener(ctx, listener), mw...)
+		e.submit(poolJob{listener: listener, event: event, data: data, result: result})
+	}
+	return result
+}
+
+// contextListener adapts listener so that its Callback passes ctx through
+// to CallbackContext when listener implements ContextListener.
+func contextListener(ctx context.Context, listener Listener) Listener {
+	cl, ok := listener.(ContextListener)
+	if !ok {
+		return listener
+	}
+	return funcListener{name: listener.Name(), cb: func(event string, data ...interface{}) {
+		cl.CallbackContext(ctx, event, data...)
+	}}
+}
+
+func (e *poolEmitter) submit(job poolJob) {
+	switch e.getPolicy() {
+	case PolicyDropNewest:
+		select {
+		case e.jobs <- job:
+		default:
+			job.result.wg.Done()
+		}
+
+	case PolicyRunInline:
+		select {
+		case e.jobs <- job:
+		default:
+			e.runJob(job)
+		}
+
+	default: // PolicyBlock
+		e.jobs <- job
+	}
+}
+
+func (e *poolEmitter) worker() {
+	for job := range e.jobs {
+		e.runJob(job)
+	}
+}
+
+func (e *poolEmitter) runJob(job poolJob) {
+	defer job.result.wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			job.result.recoverPanic(r)
+			e.handleError(job.event, job.listener.Name(), r)
+		}
+	}()
+	job.listener.Callback(job.event, job.data...)
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import "testing"
+
+func BenchmarkEmitAsyncUnbounded(b *testing.B) {
+	benchmarkEmitAsync(b, NewCommon(nil))
+}
+
+func BenchmarkEmitAsyncPool(b *testing.B) {
+	benchmarkEmitAsync(b, NewWithPool(nil, 8, 1024))
+}
+
+func benchmarkEmitAsync(b *testing.B, emitter Emitter) {
+	emitter.On("event", NewListener("ln", func(string, ...interface{}) {}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		emitter.EmitAsync("event").Wait()
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func ExampleNewWithPool() {
+	e := NewWithPool(nil, 2, 4)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		fmt.Printf("event=%s data=%v\n", event, data)
+	}))
+
+	e.EmitAsync("e1", "data1").Wait()
+
+	// Output:
+	// event=e1 data=[data1]
+}
+
+func TestPoolEmitterDropNewest(t *testing.T) {
+	started := make(chan struct{}, 3)
+	block := make(chan struct{})
+	var ran int32
+
+	e := NewWithPool(nil, 1, 1).(PoolEmitter)
+	e.SetPolicy(PolicyDropNewest)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		started <- struct{}{}
+		<-block
+		atomic.AddInt32(&ran, 1)
+	}))
+
+	r1 := e.EmitAsync("e1") // picked up by the only worker, which then blocks
+	<-started
+
+	r2 := e.EmitAsync("e1") // buffered in the size-1 queue
+	r3 := e.EmitAsync("e1") // queue full and the worker is busy: dropped
+
+	close(block)
+	<-started // the buffered job (r2) now runs
+	r1.Wait()
+	r2.Wait()
+	r3.Wait()
+
+	if n := atomic.LoadInt32(&ran); n != 2 {
+		t.Errorf("expect 2 listener calls to run, but got %d", n)
+	}
+}
+
+func TestPoolEmitterRunInline(t *testing.T) {
+	started := make(chan struct{}, 1)
+	block := make(chan struct{})
+	var calls int32
+
+	e := NewWithPool(nil, 1, 1).(PoolEmitter)
+	e.SetPolicy(PolicyRunInline)
+
+	callerGoroutine := make(chan bool, 1)
+	e.On("e1", NewListener("ln1", func(event string, data ...interface{}) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			started <- struct{}{}
+			<-block
+		} else {
+			callerGoroutine <- true
+		}
+	}))
+
+	e.EmitAsync("e1") // occupies the worker, which blocks
+	<-started
+
+	e.EmitAsync("e1") // fills the size-1 queue
+	e.EmitAsync("e1") // queue full: runs inline on this goroutine
+
+	// The only worker is still blocked, so only an inline run can have
+	// reported by the time EmitAsync returned.
+	select {
+	case <-callerGoroutine:
+	default:
+		t.Error("expect the third job to have run synchronously on the caller")
+	}
+	close(block)
+}
+
+func TestPoolEmitterEmitAsyncWaitErr(t *testing.T) {
+	e := NewWithPool(nil, 1, 1)
+	e.On("e1", NewListener("panicker", func(string, ...interface{}) { panic("boom") }))
+
+	if p := e.EmitAsync("e1").WaitErr(); p != "boom" {
+		t.Errorf("expect WaitErr to return the recovered panic %q, got %v", "boom", p)
+	}
+}
+
+func TestPoolEmitterUseAppliesToEmitAsync(t *testing.T) {
+	called := false
+
+	e := NewWithPool(nil, 1, 1).(interface {
+		PoolEmitter
+		MiddlewareEmitter
+	})
+	e.Use(FilterMiddleware(func(string, ...interface{}) bool { return false }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	e.EmitAsync("e1").Wait()
+	if called {
+		t.Error("expect the middleware chain to also apply to a pool emitter's EmitAsync")
+	}
+}
+
+func TestPoolEmitterEmitContextUsesPool(t *testing.T) {
+	block := make(chan struct{})
+
+	e := NewWithPool(nil, 1, 0).(interface {
+		PoolEmitter
+		ContextEmitter
+	})
+	e.On("busy", NewListener("ln0", func(string, ...interface{}) { <-block }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) {}))
+
+	busy := e.EmitAsync("busy") // occupies the only worker, which then blocks
+
+	done := make(chan error, 1)
+	go func() { done <- e.EmitContext(context.Background(), "e1") }()
+
+	select {
+	case <-done:
+		t.Error("expect EmitContext to wait for the busy 1-worker pool")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(block)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	busy.Wait()
+}
+
+func TestPoolEmitterEmitAsyncContextUsesPool(t *testing.T) {
+	called := false
+
+	e := NewWithPool(nil, 1, 1).(interface {
+		PoolEmitter
+		ContextEmitter
+		MiddlewareEmitter
+	})
+	e.Use(FilterMiddleware(func(string, ...interface{}) bool { return false }))
+	e.On("e1", NewListener("ln1", func(string, ...interface{}) { called = true }))
+
+	e.EmitAsyncContext(context.Background(), "e1").Wait()
+	if called {
+		t.Error("expect the middleware chain to also apply to a pool emitter's EmitAsyncContext")
+	}
+}
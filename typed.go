@@ -0,0 +1,113 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Type represents a strongly-typed event that carries a payload of type T,
+// instead of the untyped, positional data of Emit and On.
+type Type[T any] struct{ name string }
+
+// Typed returns the strongly-typed event identified by name.
+func Typed[T any](name string) Type[T] { return Type[T]{name: name} }
+
+// Name returns the name of the event.
+func (t Type[T]) Name() string { return t.name }
+
+// TypeErrorHandler is called by OnTyped instead of panicking when a typed
+// listener receives an event whose payload cannot be asserted to the
+// expected type, which may happen if an untyped caller emits the same
+// event name with a mismatched data value.
+type TypeErrorHandler func(event, listenerName string, err error)
+
+func defaultTypeErrorHandler(event, listenerName string, err error) {
+	log.Printf("event: typed listener '%s' on event '%s': %s", listenerName, event, err)
+}
+
+// TypedEmitter is implemented by the Emitters, such as the ones returned by
+// New and NewCommon, that let OnTyped report a payload type mismatch
+// through a per-Emitter, replaceable handler.
+type TypedEmitter interface {
+	Emitter
+
+	// SetTypeErrorHandler replaces the handler that OnTyped's listeners
+	// call on a payload type mismatch. A nil handler restores the
+	// default, which logs the mismatch.
+	SetTypeErrorHandler(handler TypeErrorHandler)
+}
+
+func (e *emitter) SetTypeErrorHandler(handler TypeErrorHandler) {
+	if handler == nil {
+		handler = defaultTypeErrorHandler
+	}
+	e.typeErrh.Store(handler)
+}
+
+func (e *emitter) typeErrorHandler() TypeErrorHandler {
+	if h, ok := e.typeErrh.Load().(TypeErrorHandler); ok {
+		return h
+	}
+	return defaultTypeErrorHandler
+}
+
+// typeErrorHandlerFor returns the TypeErrorHandler that e reports payload
+// type mismatches through, falling back to defaultTypeErrorHandler for an
+// Emitter that isn't a TypedEmitter.
+func typeErrorHandlerFor(e Emitter) TypeErrorHandler {
+	if te, ok := e.(interface{ typeErrorHandler() TypeErrorHandler }); ok {
+		return te.typeErrorHandler()
+	}
+	return defaultTypeErrorHandler
+}
+
+// OnTyped registers fn as the listener named name for the strongly-typed
+// event evt.
+//
+// Internally it's stored as a regular listener on e, so On, Off and Emit
+// keep working as they do for untyped events, and an untyped listener
+// registered for evt.Name() still observes the emitted payload as data[0].
+func OnTyped[T any](e Emitter, evt Type[T], name string, fn func(context.Context, T)) {
+	e.On(evt.name, NewListener(name, func(event string, data ...interface{}) {
+		if len(data) != 1 {
+			typeErrorHandlerFor(e)(event, name, fmt.Errorf(
+				"typed listener expects exactly 1 payload, but got %d", len(data)))
+			return
+		}
+
+		payload, ok := data[0].(T)
+		if !ok {
+			typeErrorHandlerFor(e)(event, name, fmt.Errorf(
+				"typed listener expects a payload of type %T, but got %T", payload, data[0]))
+			return
+		}
+
+		fn(context.Background(), payload)
+	}))
+}
+
+// EmitTyped fires evt synchronously with payload, which mirrors Emit.
+func EmitTyped[T any](e Emitter, evt Type[T], payload T) {
+	e.Emit(evt.name, payload)
+}
+
+// EmitTypedAsync is the same as EmitTyped, but triggers the listeners
+// asynchronously, which mirrors EmitAsync.
+func EmitTypedAsync[T any](e Emitter, evt Type[T], payload T) Result {
+	return e.EmitAsync(evt.name, payload)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type bulkheadPermittedEvent struct {
+	Name string
+}
+
+func ExampleOnTyped() {
+	evt := Typed[bulkheadPermittedEvent]("bulkhead.permitted")
+
+	e := New()
+	OnTyped(e, evt, "ln1", func(ctx context.Context, ev bulkheadPermittedEvent) {
+		fmt.Println("permitted:", ev.Name)
+	})
+
+	EmitTyped(e, evt, bulkheadPermittedEvent{Name: "b1"})
+	EmitTypedAsync(e, evt, bulkheadPermittedEvent{Name: "b2"}).Wait()
+
+	// Output:
+	// permitted: b1
+	// permitted: b2
+}
+
+func ExampleOnTyped_untypedListener() {
+	evt := Typed[bulkheadPermittedEvent]("bulkhead.permitted")
+
+	e := New()
+	e.On(evt.Name(), NewListener("untyped", func(event string, data ...interface{}) {
+		fmt.Printf("event=%s data=%v\n", event, data)
+	}))
+
+	EmitTyped(e, evt, bulkheadPermittedEvent{Name: "b1"})
+
+	// Output:
+	// event=bulkhead.permitted data=[{b1}]
+}
+
+func TestOnTypedMismatch(t *testing.T) {
+	evt := Typed[bulkheadPermittedEvent]("bulkhead.permitted")
+
+	var gotEvent, gotListener string
+	var gotErr error
+
+	e := New().(TypedEmitter)
+	e.SetTypeErrorHandler(func(event, listenerName string, err error) {
+		gotEvent, gotListener, gotErr = event, listenerName, err
+	})
+
+	OnTyped[bulkheadPermittedEvent](e, evt, "ln1", func(ctx context.Context, ev bulkheadPermittedEvent) {
+		t.Fatal("the typed listener must not be called on a type mismatch")
+	})
+
+	e.Emit(evt.Name(), "not-a-bulkhead-event")
+
+	if gotEvent != evt.Name() || gotListener != "ln1" || gotErr == nil {
+		t.Errorf("expect the type error handler to be called, got event=%q listener=%q err=%v",
+			gotEvent, gotListener, gotErr)
+	}
+}
+
+func TestOnTypedMismatchConcurrentSetHandler(t *testing.T) {
+	evt := Typed[bulkheadPermittedEvent]("bulkhead.permitted")
+
+	e := New().(TypedEmitter)
+	OnTyped[bulkheadPermittedEvent](e, evt, "ln1", func(ctx context.Context, ev bulkheadPermittedEvent) {
+		t.Error("the typed listener must not be called on a type mismatch")
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			e.Emit(evt.Name(), "not-a-bulkhead-event")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			e.SetTypeErrorHandler(func(event, listenerName string, err error) {})
+		}
+	}()
+	wg.Wait()
+}